@@ -3,13 +3,47 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 type Config struct {
-	DbURL           string `json:"db_url"`
-	CurrentUserName string `json:"current_user_name"`
+	DbURL           string      `json:"db_url"`
+	CurrentUserName string      `json:"current_user_name"`
+	UserAgent       string      `json:"user_agent,omitempty"`
+	WebhookURL      string      `json:"webhook_url,omitempty"`
+	MaxFeedBytes    int64       `json:"max_feed_bytes,omitempty"`
+	StoreRaw        bool        `json:"store_raw,omitempty"`
+	SMTP            *SMTPConfig `json:"smtp,omitempty"`
+	// How long after a successful fetch a feed is due again, as a Go
+	// duration, for feeds that don't give a ttl of their own.
+	DefaultFetchInterval string `json:"default_fetch_interval,omitempty"`
+	// Users who may rename other users.
+	Admins []string `json:"admins,omitempty"`
+	// The database's connection details one by one, for when db_url is empty.
+	DbHost     string `json:"db_host,omitempty"`
+	DbPort     string `json:"db_port,omitempty"`
+	DbUser     string `json:"db_user,omitempty"`
+	DbPassword string `json:"db_password,omitempty"`
+	DbName     string `json:"db_name,omitempty"`
+	DbSSLMode  string `json:"db_sslmode,omitempty"`
+
+	// Whether DbURL was built from the db_* fields rather than read, so
+	// that it isn't written back to the file.
+	dbURLBuilt bool
+}
+
+// SMTPConfig is where 'agg-once --digest' mails its digest, if anywhere.
+type SMTPConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
 }
 
 const configFilename = "gatorconfig.json"
@@ -30,9 +64,44 @@ func Read() (Config, error) {
 	if err != nil {
 		return Config{}, fmt.Errorf("Error reading JSON in config: %w", err)
 	}
+	if "" == config.DbURL {
+		config.DbURL = config.buildDbURL()
+		config.dbURLBuilt = "" != config.DbURL
+	}
 	return config, nil
 }
 
+// buildDbURL assembles a postgres:// URL from whichever db_* fields are set,
+// or returns "" if none are.
+func (c *Config) buildDbURL() string {
+	if "" == c.DbHost && "" == c.DbPort && "" == c.DbUser &&
+		"" == c.DbPassword && "" == c.DbName && "" == c.DbSSLMode {
+		return ""
+	}
+
+	host := c.DbHost
+	if "" == host {
+		host = "localhost"
+	}
+	if "" != c.DbPort {
+		host = net.JoinHostPort(host, c.DbPort)
+	} else if strings.Contains(host, ":") {
+		// An IPv6 address needs brackets even without a port.
+		host = "[" + host + "]"
+	}
+	dbURL := url.URL{Scheme: "postgres", Host: host, Path: "/" + c.DbName}
+	if "" != c.DbPassword {
+		dbURL.User = url.UserPassword(c.DbUser, c.DbPassword)
+	} else if "" != c.DbUser {
+		dbURL.User = url.User(c.DbUser)
+	}
+	if "" != c.DbSSLMode {
+		dbURL.RawQuery = url.Values{"sslmode": {c.DbSSLMode}}.Encode()
+	}
+
+	return dbURL.String()
+}
+
 func (c *Config) SetUser(username string) error {
 	c.CurrentUserName = username
 
@@ -44,6 +113,17 @@ func (c *Config) SetUser(username string) error {
 	return nil
 }
 
+func (c *Config) SetDefaultFetchInterval(interval string) error {
+	c.DefaultFetchInterval = interval
+
+	return writeConfig(*c)
+}
+
+// FilePath is where the config file is read from and written to.
+func FilePath() (string, error) {
+	return getConfigFilePath()
+}
+
 func getConfigFilePath() (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
@@ -58,6 +138,9 @@ func writeConfig(config Config) error {
 	if err != nil {
 		return fmt.Errorf("Error writing config file; couldn't get path: %w", err)
 	}
+	if config.dbURLBuilt {
+		config.DbURL = ""
+	}
 
 	dataBuffer, err := json.Marshal(config)
 	if err != nil {
@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,17 +15,19 @@ import (
 
 const createFeedFollow = `-- name: CreateFeedFollow :one
 WITH inserted_feed_follow AS (
-	INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id)
+	INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id, alias, paused)
 	VALUES (
 		$1,
 		$2,
 		$3,
 		$4,
-		$5
-	) RETURNING id, created_at, updated_at, user_id, feed_id
+		$5,
+		$6,
+		$7
+	) RETURNING id, created_at, updated_at, user_id, feed_id, alias, paused
 )
 SELECT
-	inserted_feed_follow.id, inserted_feed_follow.created_at, inserted_feed_follow.updated_at, inserted_feed_follow.user_id, inserted_feed_follow.feed_id,
+	inserted_feed_follow.id, inserted_feed_follow.created_at, inserted_feed_follow.updated_at, inserted_feed_follow.user_id, inserted_feed_follow.feed_id, inserted_feed_follow.alias, inserted_feed_follow.paused,
 	feeds.name AS feed_name,
 	users.name AS user_name
 FROM inserted_feed_follow
@@ -38,6 +41,8 @@ type CreateFeedFollowParams struct {
 	UpdatedAt time.Time
 	UserID    uuid.UUID
 	FeedID    uuid.UUID
+	Alias     sql.NullString
+	Paused    bool
 }
 
 type CreateFeedFollowRow struct {
@@ -46,6 +51,8 @@ type CreateFeedFollowRow struct {
 	UpdatedAt time.Time
 	UserID    uuid.UUID
 	FeedID    uuid.UUID
+	Alias     sql.NullString
+	Paused    bool
 	FeedName  string
 	UserName  string
 }
@@ -57,6 +64,8 @@ func (q *Queries) CreateFeedFollow(ctx context.Context, arg CreateFeedFollowPara
 		arg.UpdatedAt,
 		arg.UserID,
 		arg.FeedID,
+		arg.Alias,
+		arg.Paused,
 	)
 	var i CreateFeedFollowRow
 	err := row.Scan(
@@ -65,29 +74,88 @@ func (q *Queries) CreateFeedFollow(ctx context.Context, arg CreateFeedFollowPara
 		&i.UpdatedAt,
 		&i.UserID,
 		&i.FeedID,
+		&i.Alias,
+		&i.Paused,
 		&i.FeedName,
 		&i.UserName,
 	)
 	return i, err
 }
 
+const followAllFeeds = `-- name: FollowAllFeeds :execrows
+INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id)
+SELECT gen_random_uuid(), LOCALTIMESTAMP, LOCALTIMESTAMP, $1, feeds.id
+FROM feeds
+WHERE NOT EXISTS (
+	SELECT 1 FROM feed_follows
+	WHERE feed_follows.user_id = $1 AND feed_follows.feed_id = feeds.id
+)
+`
+
+func (q *Queries) FollowAllFeeds(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, followAllFeeds, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getFeedFollowers = `-- name: GetFeedFollowers :many
+SELECT users.name FROM feed_follows
+	INNER JOIN users ON feed_follows.user_id = users.id
+WHERE feed_follows.feed_id = $1
+ORDER BY users.name
+`
+
+func (q *Queries) GetFeedFollowers(ctx context.Context, feedID uuid.UUID) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getFeedFollowers, feedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFeedFollowsForUser = `-- name: GetFeedFollowsForUser :many
-SELECT feed_follows.id, feed_follows.created_at, feed_follows.updated_at, feed_follows.user_id, feed_follows.feed_id, users.name AS user_name, feeds.name AS feed_name, feeds.url AS url
+SELECT feed_follows.id, feed_follows.created_at, feed_follows.updated_at, feed_follows.user_id, feed_follows.feed_id, feed_follows.alias, feed_follows.paused, users.name AS user_name,
+	COALESCE(feed_follows.alias, feeds.name) AS feed_name, feeds.url AS url,
+	owners.name AS owner_name,
+	(SELECT MAX(posts.published_at) FROM posts WHERE posts.feed_id = feeds.id)::timestamp AS latest_post_at
 FROM feed_follows
 	INNER JOIN users ON feed_follows.user_id = users.id
 	INNER JOIN feeds ON feed_follows.feed_id = feeds.id
+	INNER JOIN users AS owners ON feeds.user_id = owners.id
 WHERE users.id = $1
+ORDER BY feed_name
 `
 
 type GetFeedFollowsForUserRow struct {
-	ID        uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	UserID    uuid.UUID
-	FeedID    uuid.UUID
-	UserName  string
-	FeedName  string
-	Url       string
+	ID           uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	UserID       uuid.UUID
+	FeedID       uuid.UUID
+	Alias        sql.NullString
+	Paused       bool
+	UserName     string
+	FeedName     string
+	Url          string
+	OwnerName    string
+	LatestPostAt sql.NullTime
 }
 
 func (q *Queries) GetFeedFollowsForUser(ctx context.Context, id uuid.UUID) ([]GetFeedFollowsForUserRow, error) {
@@ -105,9 +173,115 @@ func (q *Queries) GetFeedFollowsForUser(ctx context.Context, id uuid.UUID) ([]Ge
 			&i.UpdatedAt,
 			&i.UserID,
 			&i.FeedID,
+			&i.Alias,
+			&i.Paused,
 			&i.UserName,
 			&i.FeedName,
 			&i.Url,
+			&i.OwnerName,
+			&i.LatestPostAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const mergeFeedFollows = `-- name: MergeFeedFollows :exec
+UPDATE feed_follows AS kept
+SET alias = COALESCE(kept.alias, merged.alias),
+	paused = kept.paused AND merged.paused,
+	updated_at = LOCALTIMESTAMP
+FROM feed_follows AS merged
+WHERE kept.feed_id = $1
+	AND merged.feed_id = $2
+	AND merged.user_id = kept.user_id
+`
+
+type MergeFeedFollowsParams struct {
+	ToFeedID   uuid.UUID
+	FromFeedID uuid.UUID
+}
+
+func (q *Queries) MergeFeedFollows(ctx context.Context, arg MergeFeedFollowsParams) error {
+	_, err := q.db.ExecContext(ctx, mergeFeedFollows, arg.ToFeedID, arg.FromFeedID)
+	return err
+}
+
+const moveFeedFollows = `-- name: MoveFeedFollows :execrows
+UPDATE feed_follows
+SET feed_id = $1, updated_at = LOCALTIMESTAMP
+WHERE feed_id = $2
+	AND NOT EXISTS (
+		SELECT 1 FROM feed_follows AS kept
+		WHERE kept.feed_id = $1
+			AND kept.user_id = feed_follows.user_id
+	)
+`
+
+type MoveFeedFollowsParams struct {
+	ToFeedID   uuid.UUID
+	FromFeedID uuid.UUID
+}
+
+func (q *Queries) MoveFeedFollows(ctx context.Context, arg MoveFeedFollowsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, moveFeedFollows, arg.ToFeedID, arg.FromFeedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const recommendFeeds = `-- name: RecommendFeeds :many
+SELECT feeds.id, feeds.name, feeds.url, COUNT(*) AS overlap
+FROM feed_follows AS mine
+	INNER JOIN feed_follows AS peers
+		ON peers.feed_id = mine.feed_id AND peers.user_id <> mine.user_id
+	INNER JOIN feed_follows AS theirs ON theirs.user_id = peers.user_id
+	INNER JOIN feeds ON theirs.feed_id = feeds.id
+WHERE mine.user_id = $1
+	AND NOT EXISTS (
+		SELECT 1 FROM feed_follows
+		WHERE feed_follows.user_id = $1 AND feed_follows.feed_id = feeds.id
+	)
+GROUP BY feeds.id, feeds.name, feeds.url
+ORDER BY overlap DESC, feeds.name
+LIMIT $2
+`
+
+type RecommendFeedsParams struct {
+	UserID   uuid.UUID
+	MaxFeeds int32
+}
+
+type RecommendFeedsRow struct {
+	ID      uuid.UUID
+	Name    string
+	Url     string
+	Overlap int64
+}
+
+func (q *Queries) RecommendFeeds(ctx context.Context, arg RecommendFeedsParams) ([]RecommendFeedsRow, error) {
+	rows, err := q.db.QueryContext(ctx, recommendFeeds, arg.UserID, arg.MaxFeeds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RecommendFeedsRow
+	for rows.Next() {
+		var i RecommendFeedsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Url,
+			&i.Overlap,
 		); err != nil {
 			return nil, err
 		}
@@ -122,6 +296,43 @@ func (q *Queries) GetFeedFollowsForUser(ctx context.Context, id uuid.UUID) ([]Ge
 	return items, nil
 }
 
+const setFeedFollowAlias = `-- name: SetFeedFollowAlias :exec
+UPDATE feed_follows
+SET alias = $3, updated_at = LOCALTIMESTAMP
+WHERE user_id = $1 AND feed_id = $2
+`
+
+type SetFeedFollowAliasParams struct {
+	UserID uuid.UUID
+	FeedID uuid.UUID
+	Alias  sql.NullString
+}
+
+func (q *Queries) SetFeedFollowAlias(ctx context.Context, arg SetFeedFollowAliasParams) error {
+	_, err := q.db.ExecContext(ctx, setFeedFollowAlias, arg.UserID, arg.FeedID, arg.Alias)
+	return err
+}
+
+const setFeedFollowPaused = `-- name: SetFeedFollowPaused :execrows
+UPDATE feed_follows
+SET paused = $3, updated_at = LOCALTIMESTAMP
+WHERE user_id = $1 AND feed_id = $2
+`
+
+type SetFeedFollowPausedParams struct {
+	UserID uuid.UUID
+	FeedID uuid.UUID
+	Paused bool
+}
+
+func (q *Queries) SetFeedFollowPaused(ctx context.Context, arg SetFeedFollowPausedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setFeedFollowPaused, arg.UserID, arg.FeedID, arg.Paused)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const unfollowFeed = `-- name: UnfollowFeed :exec
 DELETE FROM feed_follows WHERE user_id = $1 AND feed_id = $2
 `
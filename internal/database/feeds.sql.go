@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,7 +23,7 @@ VALUES (
 	$5,
 	$6
 )
-RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at
+RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at, last_attempt_at, retry_after_at, auth_token, failure_count, ttl_minutes
 `
 
 type CreateFeedParams struct {
@@ -52,12 +53,26 @@ func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) (Feed, e
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.LastAttemptAt,
+		&i.RetryAfterAt,
+		&i.AuthToken,
+		&i.FailureCount,
+		&i.TtlMinutes,
 	)
 	return i, err
 }
 
+const deleteFeed = `-- name: DeleteFeed :exec
+DELETE FROM feeds WHERE id = $1
+`
+
+func (q *Queries) DeleteFeed(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteFeed, id)
+	return err
+}
+
 const getFeedByURL = `-- name: GetFeedByURL :one
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at FROM feeds WHERE url = $1
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, last_attempt_at, retry_after_at, auth_token, failure_count, ttl_minutes FROM feeds WHERE url = $1
 `
 
 func (q *Queries) GetFeedByURL(ctx context.Context, url string) (Feed, error) {
@@ -71,23 +86,48 @@ func (q *Queries) GetFeedByURL(ctx context.Context, url string) (Feed, error) {
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.LastAttemptAt,
+		&i.RetryAfterAt,
+		&i.AuthToken,
+		&i.FailureCount,
+		&i.TtlMinutes,
 	)
 	return i, err
 }
 
 const getFeeds = `-- name: GetFeeds :many
-SELECT feeds.name, feeds.url, users.name AS username
+SELECT feeds.name, feeds.url, users.name AS username, feeds.last_fetched_at,
+	feeds.created_at, feeds.failure_count,
+	(SELECT COUNT(*) FROM posts WHERE posts.feed_id = feeds.id) AS post_count,
+	(SELECT COUNT(*) FROM feed_follows WHERE feed_follows.feed_id = feeds.id) AS follower_count,
+	(SELECT MAX(posts.published_at) FROM posts WHERE posts.feed_id = feeds.id)::timestamp AS newest_post_at
 FROM feeds INNER JOIN users ON feeds.user_id = users.id
+WHERE ($1::text IS NULL OR users.name = $1)
+	AND ($2::timestamp IS NULL
+		OR feeds.created_at > $2)
+ORDER BY
+	CASE WHEN $2::timestamp IS NOT NULL THEN feeds.created_at END DESC
 `
 
+type GetFeedsParams struct {
+	Owner      sql.NullString
+	AddedAfter sql.NullTime
+}
+
 type GetFeedsRow struct {
-	Name     string
-	Url      string
-	Username string
+	Name          string
+	Url           string
+	Username      string
+	LastFetchedAt sql.NullTime
+	CreatedAt     time.Time
+	FailureCount  int32
+	PostCount     int64
+	FollowerCount int64
+	NewestPostAt  sql.NullTime
 }
 
-func (q *Queries) GetFeeds(ctx context.Context) ([]GetFeedsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getFeeds)
+func (q *Queries) GetFeeds(ctx context.Context, arg GetFeedsParams) ([]GetFeedsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFeeds, arg.Owner, arg.AddedAfter)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +135,109 @@ func (q *Queries) GetFeeds(ctx context.Context) ([]GetFeedsRow, error) {
 	var items []GetFeedsRow
 	for rows.Next() {
 		var i GetFeedsRow
-		if err := rows.Scan(&i.Name, &i.Url, &i.Username); err != nil {
+		if err := rows.Scan(
+			&i.Name,
+			&i.Url,
+			&i.Username,
+			&i.LastFetchedAt,
+			&i.CreatedAt,
+			&i.FailureCount,
+			&i.PostCount,
+			&i.FollowerCount,
+			&i.NewestPostAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFeedsByName = `-- name: GetFeedsByName :many
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, last_attempt_at, retry_after_at, auth_token, failure_count, ttl_minutes FROM feeds WHERE name = $1 ORDER BY created_at
+`
+
+func (q *Queries) GetFeedsByName(ctx context.Context, name string) ([]Feed, error) {
+	rows, err := q.db.QueryContext(ctx, getFeedsByName, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Feed
+	for rows.Next() {
+		var i Feed
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Url,
+			&i.UserID,
+			&i.LastFetchedAt,
+			&i.LastAttemptAt,
+			&i.RetryAfterAt,
+			&i.AuthToken,
+			&i.FailureCount,
+			&i.TtlMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFeedsToFetch = `-- name: GetFeedsToFetch :many
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, last_attempt_at, retry_after_at, auth_token, failure_count, ttl_minutes FROM feeds
+WHERE (retry_after_at IS NULL OR retry_after_at <= LOCALTIMESTAMP)
+	AND (NOT $1::boolean OR EXISTS (
+		SELECT 1 FROM feed_follows WHERE feed_follows.feed_id = feeds.id))
+	AND (last_fetched_at IS NULL
+		OR last_fetched_at + COALESCE(make_interval(mins => ttl_minutes),
+			make_interval(secs => $2::float8)) <= LOCALTIMESTAMP)
+ORDER BY last_attempt_at NULLS FIRST
+`
+
+type GetFeedsToFetchParams struct {
+	FollowedOnly           bool
+	DefaultIntervalSeconds float64
+}
+
+func (q *Queries) GetFeedsToFetch(ctx context.Context, arg GetFeedsToFetchParams) ([]Feed, error) {
+	rows, err := q.db.QueryContext(ctx, getFeedsToFetch, arg.FollowedOnly, arg.DefaultIntervalSeconds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Feed
+	for rows.Next() {
+		var i Feed
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Url,
+			&i.UserID,
+			&i.LastFetchedAt,
+			&i.LastAttemptAt,
+			&i.RetryAfterAt,
+			&i.AuthToken,
+			&i.FailureCount,
+			&i.TtlMinutes,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -110,13 +252,58 @@ func (q *Queries) GetFeeds(ctx context.Context) ([]GetFeedsRow, error) {
 }
 
 const getNextFeedToFetch = `-- name: GetNextFeedToFetch :one
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at FROM feeds
-ORDER BY last_fetched_at NULLS FIRST
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, last_attempt_at, retry_after_at, auth_token, failure_count, ttl_minutes FROM feeds
+WHERE (retry_after_at IS NULL OR retry_after_at <= LOCALTIMESTAMP)
+	AND (last_fetched_at IS NULL
+		OR last_fetched_at + COALESCE(make_interval(mins => ttl_minutes),
+			make_interval(secs => $1::float8)) <= LOCALTIMESTAMP)
+ORDER BY last_attempt_at + make_interval(secs => random() * $2::float8) NULLS FIRST
+FETCH FIRST ROW ONLY
+`
+
+type GetNextFeedToFetchParams struct {
+	DefaultIntervalSeconds float64
+	JitterSeconds          float64
+}
+
+func (q *Queries) GetNextFeedToFetch(ctx context.Context, arg GetNextFeedToFetchParams) (Feed, error) {
+	row := q.db.QueryRowContext(ctx, getNextFeedToFetch, arg.DefaultIntervalSeconds, arg.JitterSeconds)
+	var i Feed
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Url,
+		&i.UserID,
+		&i.LastFetchedAt,
+		&i.LastAttemptAt,
+		&i.RetryAfterAt,
+		&i.AuthToken,
+		&i.FailureCount,
+		&i.TtlMinutes,
+	)
+	return i, err
+}
+
+const getNextFollowedFeedToFetch = `-- name: GetNextFollowedFeedToFetch :one
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, last_attempt_at, retry_after_at, auth_token, failure_count, ttl_minutes FROM feeds
+WHERE EXISTS (SELECT 1 FROM feed_follows WHERE feed_follows.feed_id = feeds.id)
+	AND (retry_after_at IS NULL OR retry_after_at <= LOCALTIMESTAMP)
+	AND (last_fetched_at IS NULL
+		OR last_fetched_at + COALESCE(make_interval(mins => ttl_minutes),
+			make_interval(secs => $1::float8)) <= LOCALTIMESTAMP)
+ORDER BY last_attempt_at + make_interval(secs => random() * $2::float8) NULLS FIRST
 FETCH FIRST ROW ONLY
 `
 
-func (q *Queries) GetNextFeedToFetch(ctx context.Context) (Feed, error) {
-	row := q.db.QueryRowContext(ctx, getNextFeedToFetch)
+type GetNextFollowedFeedToFetchParams struct {
+	DefaultIntervalSeconds float64
+	JitterSeconds          float64
+}
+
+func (q *Queries) GetNextFollowedFeedToFetch(ctx context.Context, arg GetNextFollowedFeedToFetchParams) (Feed, error) {
+	row := q.db.QueryRowContext(ctx, getNextFollowedFeedToFetch, arg.DefaultIntervalSeconds, arg.JitterSeconds)
 	var i Feed
 	err := row.Scan(
 		&i.ID,
@@ -126,13 +313,82 @@ func (q *Queries) GetNextFeedToFetch(ctx context.Context) (Feed, error) {
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.LastAttemptAt,
+		&i.RetryAfterAt,
+		&i.AuthToken,
+		&i.FailureCount,
+		&i.TtlMinutes,
 	)
 	return i, err
 }
 
+const listFeedsForUser = `-- name: ListFeedsForUser :many
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, last_attempt_at, retry_after_at, auth_token, failure_count, ttl_minutes FROM feeds
+WHERE feeds.user_id = $1
+	OR (NOT $2::boolean AND EXISTS (
+		SELECT 1 FROM feed_follows
+		WHERE feed_follows.feed_id = feeds.id
+			AND feed_follows.user_id = $1))
+ORDER BY name
+`
+
+type ListFeedsForUserParams struct {
+	UserID    uuid.UUID
+	OwnedOnly bool
+}
+
+func (q *Queries) ListFeedsForUser(ctx context.Context, arg ListFeedsForUserParams) ([]Feed, error) {
+	rows, err := q.db.QueryContext(ctx, listFeedsForUser, arg.UserID, arg.OwnedOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Feed
+	for rows.Next() {
+		var i Feed
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Url,
+			&i.UserID,
+			&i.LastFetchedAt,
+			&i.LastAttemptAt,
+			&i.RetryAfterAt,
+			&i.AuthToken,
+			&i.FailureCount,
+			&i.TtlMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markFeedFailed = `-- name: MarkFeedFailed :exec
+UPDATE feeds
+SET last_attempt_at = LOCALTIMESTAMP, failure_count = failure_count + 1,
+	updated_at = LOCALTIMESTAMP
+WHERE id = $1
+`
+
+func (q *Queries) MarkFeedFailed(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, markFeedFailed, id)
+	return err
+}
+
 const markFeedFetched = `-- name: MarkFeedFetched :exec
 UPDATE feeds
-SET last_fetched_at = LOCALTIMESTAMP, updated_at = LOCALTIMESTAMP
+SET last_fetched_at = LOCALTIMESTAMP, last_attempt_at = LOCALTIMESTAMP,
+	failure_count = 0, updated_at = LOCALTIMESTAMP
 WHERE id = $1
 `
 
@@ -140,3 +396,67 @@ func (q *Queries) MarkFeedFetched(ctx context.Context, id uuid.UUID) error {
 	_, err := q.db.ExecContext(ctx, markFeedFetched, id)
 	return err
 }
+
+const setFeedAuthToken = `-- name: SetFeedAuthToken :exec
+UPDATE feeds
+SET auth_token = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1
+`
+
+type SetFeedAuthTokenParams struct {
+	ID        uuid.UUID
+	AuthToken sql.NullString
+}
+
+func (q *Queries) SetFeedAuthToken(ctx context.Context, arg SetFeedAuthTokenParams) error {
+	_, err := q.db.ExecContext(ctx, setFeedAuthToken, arg.ID, arg.AuthToken)
+	return err
+}
+
+const setFeedName = `-- name: SetFeedName :exec
+UPDATE feeds
+SET name = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1
+`
+
+type SetFeedNameParams struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (q *Queries) SetFeedName(ctx context.Context, arg SetFeedNameParams) error {
+	_, err := q.db.ExecContext(ctx, setFeedName, arg.ID, arg.Name)
+	return err
+}
+
+const setFeedRetryAfter = `-- name: SetFeedRetryAfter :exec
+UPDATE feeds
+SET retry_after_at = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1
+`
+
+type SetFeedRetryAfterParams struct {
+	ID           uuid.UUID
+	RetryAfterAt sql.NullTime
+}
+
+func (q *Queries) SetFeedRetryAfter(ctx context.Context, arg SetFeedRetryAfterParams) error {
+	_, err := q.db.ExecContext(ctx, setFeedRetryAfter, arg.ID, arg.RetryAfterAt)
+	return err
+}
+
+const setFeedTTL = `-- name: SetFeedTTL :exec
+UPDATE feeds
+SET ttl_minutes = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1
+`
+
+type SetFeedTTLParams struct {
+	ID         uuid.UUID
+	TtlMinutes sql.NullInt32
+}
+
+func (q *Queries) SetFeedTTL(ctx context.Context, arg SetFeedTTLParams) error {
+	_, err := q.db.ExecContext(ctx, setFeedTTL, arg.ID, arg.TtlMinutes)
+	return err
+}
@@ -11,6 +11,12 @@ import (
 	"github.com/google/uuid"
 )
 
+type AppState struct {
+	Name      string
+	Value     string
+	UpdatedAt time.Time
+}
+
 type Feed struct {
 	ID            uuid.UUID
 	CreatedAt     time.Time
@@ -19,6 +25,20 @@ type Feed struct {
 	Url           string
 	UserID        uuid.UUID
 	LastFetchedAt sql.NullTime
+	LastAttemptAt sql.NullTime
+	RetryAfterAt  sql.NullTime
+	AuthToken     sql.NullString
+	FailureCount  int32
+	TtlMinutes    sql.NullInt32
+}
+
+type FeedFetchLog struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	FeedID    uuid.UUID
+	Status    string
+	Items     int32
+	Error     sql.NullString
 }
 
 type FeedFollow struct {
@@ -27,22 +47,61 @@ type FeedFollow struct {
 	UpdatedAt time.Time
 	UserID    uuid.UUID
 	FeedID    uuid.UUID
+	Alias     sql.NullString
+	Paused    bool
+}
+
+type FeedRaw struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	FeedID    uuid.UUID
+	Body      []byte
+}
+
+type FeedTag struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	FeedID    uuid.UUID
+	Tag       string
 }
 
 type Post struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Url         string
-	Description string
-	PublishedAt time.Time
-	FeedID      uuid.UUID
+	ID               uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Title            string
+	Url              string
+	Description      string
+	PublishedAt      time.Time
+	FeedID           uuid.UUID
+	PlainDescription string
+	Content          string
+	WordCount        int32
 }
 
-type User struct {
+type PostStar struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
 	UpdatedAt time.Time
-	Name      string
+	UserID    uuid.UUID
+	PostID    uuid.UUID
+}
+
+type PostsMedium struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	PostID    uuid.UUID
+	Url       string
+	MediaType string
+	Length    sql.NullInt64
+}
+
+type User struct {
+	ID             uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Name           string
+	LastReadPostAt sql.NullTime
 }
@@ -7,27 +7,73 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const countPostsForUser = `-- name: CountPostsForUser :one
+SELECT COUNT(*) FROM
+feed_follows
+	INNER JOIN posts ON feed_follows.feed_id = posts.feed_id
+WHERE feed_follows.user_id = $1
+	AND ($2::timestamp IS NULL
+		OR posts.published_at > $2)
+	AND ($3::text IS NULL OR posts.feed_id IN (
+		SELECT feed_tags.feed_id FROM feed_tags WHERE feed_tags.tag = $3))
+	AND ($4::uuid[] IS NULL
+		OR posts.feed_id = ANY($4::uuid[]))
+	AND (NOT $5::boolean OR EXISTS (
+		SELECT 1 FROM posts_media WHERE posts_media.post_id = posts.id))
+	AND ($6::boolean OR NOT feed_follows.paused)
+`
+
+type CountPostsForUserParams struct {
+	UserID         uuid.UUID
+	PublishedAfter sql.NullTime
+	Tag            sql.NullString
+	FeedIds        []uuid.UUID
+	HasMedia       bool
+	IncludePaused  bool
+}
+
+func (q *Queries) CountPostsForUser(ctx context.Context, arg CountPostsForUserParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPostsForUser,
+		arg.UserID,
+		arg.PublishedAfter,
+		arg.Tag,
+		pq.Array(arg.FeedIds),
+		arg.HasMedia,
+		arg.IncludePaused,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createPost = `-- name: CreatePost :one
 INSERT INTO posts
-(id, created_at, updated_at, title, url, description, published_at, feed_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, created_at, updated_at, title, url, description, published_at, feed_id
+(id, created_at, updated_at, title, url, description, published_at, feed_id,
+	plain_description, content, word_count)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (url) DO NOTHING
+RETURNING id, created_at, updated_at, title, url, description, published_at, feed_id, plain_description, content, word_count
 `
 
 type CreatePostParams struct {
-	ID          uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Title       string
-	Url         string
-	Description string
-	PublishedAt time.Time
-	FeedID      uuid.UUID
+	ID               uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Title            string
+	Url              string
+	Description      string
+	PublishedAt      time.Time
+	FeedID           uuid.UUID
+	PlainDescription string
+	Content          string
+	WordCount        int32
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, error) {
@@ -40,6 +86,9 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		arg.Description,
 		arg.PublishedAt,
 		arg.FeedID,
+		arg.PlainDescription,
+		arg.Content,
+		arg.WordCount,
 	)
 	var i Post
 	err := row.Scan(
@@ -51,27 +100,213 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		&i.Description,
 		&i.PublishedAt,
 		&i.FeedID,
+		&i.PlainDescription,
+		&i.Content,
+		&i.WordCount,
 	)
 	return i, err
 }
 
+const deleteDuplicatePosts = `-- name: DeleteDuplicatePosts :execrows
+DELETE FROM posts
+WHERE posts.id IN (
+	SELECT later.id FROM posts AS later
+		INNER JOIN posts AS earlier
+		ON lower(trim(earlier.title)) = lower(trim(later.title))
+			AND (earlier.published_at < later.published_at
+				OR (earlier.published_at = later.published_at AND earlier.id < later.id))
+	WHERE trim(later.title) <> ''
+		AND EXTRACT(EPOCH FROM later.published_at - earlier.published_at) <= $1::float8
+		AND later.feed_id IN (SELECT feeds.id FROM feeds WHERE feeds.user_id = $2)
+		AND earlier.feed_id IN (SELECT feeds.id FROM feeds WHERE feeds.user_id = $2)
+)
+`
+
+type DeleteDuplicatePostsParams struct {
+	WindowSeconds float64
+	UserID        uuid.UUID
+}
+
+func (q *Queries) DeleteDuplicatePosts(ctx context.Context, arg DeleteDuplicatePostsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteDuplicatePosts, arg.WindowSeconds, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteFeedPosts = `-- name: DeleteFeedPosts :execrows
+DELETE FROM posts WHERE feed_id = $1
+`
+
+func (q *Queries) DeleteFeedPosts(ctx context.Context, feedID uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteFeedPosts, feedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getPostByURL = `-- name: GetPostByURL :one
+SELECT posts.id, posts.created_at, posts.updated_at, posts.title, posts.url, posts.description, posts.published_at, posts.feed_id, posts.plain_description, posts.content, posts.word_count, feeds.name AS feed_name
+FROM posts INNER JOIN feeds ON posts.feed_id = feeds.id
+WHERE posts.url = $1
+`
+
+type GetPostByURLRow struct {
+	ID               uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Title            string
+	Url              string
+	Description      string
+	PublishedAt      time.Time
+	FeedID           uuid.UUID
+	PlainDescription string
+	Content          string
+	WordCount        int32
+	FeedName         string
+}
+
+func (q *Queries) GetPostByURL(ctx context.Context, url string) (GetPostByURLRow, error) {
+	row := q.db.QueryRowContext(ctx, getPostByURL, url)
+	var i GetPostByURLRow
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Title,
+		&i.Url,
+		&i.Description,
+		&i.PublishedAt,
+		&i.FeedID,
+		&i.PlainDescription,
+		&i.Content,
+		&i.WordCount,
+		&i.FeedName,
+	)
+	return i, err
+}
+
+const getPostsCreatedAfter = `-- name: GetPostsCreatedAfter :many
+SELECT posts.id, posts.created_at, posts.updated_at, posts.title, posts.url, posts.description, posts.published_at, posts.feed_id, posts.plain_description, posts.content, posts.word_count, feeds.name AS feed_name
+FROM posts INNER JOIN feeds ON posts.feed_id = feeds.id
+WHERE posts.created_at > $1
+ORDER BY feeds.name, posts.published_at DESC
+`
+
+type GetPostsCreatedAfterRow struct {
+	ID               uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Title            string
+	Url              string
+	Description      string
+	PublishedAt      time.Time
+	FeedID           uuid.UUID
+	PlainDescription string
+	Content          string
+	WordCount        int32
+	FeedName         string
+}
+
+func (q *Queries) GetPostsCreatedAfter(ctx context.Context, createdAt time.Time) ([]GetPostsCreatedAfterRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPostsCreatedAfter, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPostsCreatedAfterRow
+	for rows.Next() {
+		var i GetPostsCreatedAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Url,
+			&i.Description,
+			&i.PublishedAt,
+			&i.FeedID,
+			&i.PlainDescription,
+			&i.Content,
+			&i.WordCount,
+			&i.FeedName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPostsForUser = `-- name: GetPostsForUser :many
-SELECT posts.id, posts.created_at, posts.updated_at, posts.title, posts.url, posts.description, posts.published_at, posts.feed_id FROM
-feed_follows
-	INNER JOIN users ON users.id = feed_follows.user_id
-	INNER JOIN posts ON feed_follows.feed_id = posts.feed_id
-WHERE users.id = $1
-ORDER BY posts.published_at DESC
-LIMIT $2
+SELECT posts.id, posts.created_at, posts.updated_at, posts.title, posts.url, posts.description, posts.published_at, posts.feed_id, posts.plain_description, posts.content, posts.word_count FROM posts
+WHERE posts.id IN (
+	SELECT ranked.id FROM (
+		SELECT posts.id,
+			ROW_NUMBER() OVER (
+				PARTITION BY posts.feed_id
+				ORDER BY
+					CASE WHEN $1::boolean THEN posts.published_at END ASC,
+					posts.published_at DESC
+			) AS feed_rank
+		FROM feed_follows
+			INNER JOIN posts ON feed_follows.feed_id = posts.feed_id
+		WHERE feed_follows.user_id = $2
+			AND ($3::timestamp IS NULL
+				OR posts.published_at > $3)
+			AND ($4::timestamp IS NULL
+				OR posts.created_at > $4)
+			AND ($5::text IS NULL OR posts.feed_id IN (
+				SELECT feed_tags.feed_id FROM feed_tags WHERE feed_tags.tag = $5))
+			AND ($6::uuid[] IS NULL
+				OR posts.feed_id = ANY($6::uuid[]))
+			AND (NOT $7::boolean OR EXISTS (
+				SELECT 1 FROM posts_media WHERE posts_media.post_id = posts.id))
+			AND ($8::boolean OR NOT feed_follows.paused)
+	) AS ranked
+	WHERE $9::int IS NULL
+		OR ranked.feed_rank <= $9
+)
+ORDER BY
+	CASE WHEN $1::boolean THEN posts.published_at END ASC,
+	posts.published_at DESC
+LIMIT $10
 `
 
 type GetPostsForUserParams struct {
-	ID    uuid.UUID
-	Limit int32
+	OldestFirst    bool
+	UserID         uuid.UUID
+	PublishedAfter sql.NullTime
+	CreatedAfter   sql.NullTime
+	Tag            sql.NullString
+	FeedIds        []uuid.UUID
+	HasMedia       bool
+	IncludePaused  bool
+	MaxPerFeed     sql.NullInt32
+	MaxPosts       sql.NullInt32
 }
 
 func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams) ([]Post, error) {
-	rows, err := q.db.QueryContext(ctx, getPostsForUser, arg.ID, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, getPostsForUser,
+		arg.OldestFirst,
+		arg.UserID,
+		arg.PublishedAfter,
+		arg.CreatedAfter,
+		arg.Tag,
+		pq.Array(arg.FeedIds),
+		arg.HasMedia,
+		arg.IncludePaused,
+		arg.MaxPerFeed,
+		arg.MaxPosts,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +323,9 @@ func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams
 			&i.Description,
 			&i.PublishedAt,
 			&i.FeedID,
+			&i.PlainDescription,
+			&i.Content,
+			&i.WordCount,
 		); err != nil {
 			return nil, err
 		}
@@ -101,3 +339,22 @@ func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams
 	}
 	return items, nil
 }
+
+const moveFeedPosts = `-- name: MoveFeedPosts :execrows
+UPDATE posts
+SET feed_id = $1, updated_at = LOCALTIMESTAMP
+WHERE feed_id = $2
+`
+
+type MoveFeedPostsParams struct {
+	ToFeedID   uuid.UUID
+	FromFeedID uuid.UUID
+}
+
+func (q *Queries) MoveFeedPosts(ctx context.Context, arg MoveFeedPostsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, moveFeedPosts, arg.ToFeedID, arg.FromFeedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -12,6 +12,34 @@ import (
 	"github.com/google/uuid"
 )
 
+const advanceLastReadPostAt = `-- name: AdvanceLastReadPostAt :exec
+UPDATE users
+SET last_read_post_at = GREATEST(last_read_post_at, $2::timestamp),
+	updated_at = LOCALTIMESTAMP
+WHERE id = $1
+`
+
+type AdvanceLastReadPostAtParams struct {
+	ID             uuid.UUID
+	LastReadPostAt time.Time
+}
+
+func (q *Queries) AdvanceLastReadPostAt(ctx context.Context, arg AdvanceLastReadPostAtParams) error {
+	_, err := q.db.ExecContext(ctx, advanceLastReadPostAt, arg.ID, arg.LastReadPostAt)
+	return err
+}
+
+const clearLastReadPostAt = `-- name: ClearLastReadPostAt :exec
+UPDATE users
+SET last_read_post_at = NULL, updated_at = LOCALTIMESTAMP
+WHERE id = $1
+`
+
+func (q *Queries) ClearLastReadPostAt(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, clearLastReadPostAt, id)
+	return err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (id, created_at, updated_at, name)
 VALUES (
@@ -20,7 +48,7 @@ VALUES (
 	$3,
 	$4
 	)
-RETURNING id, created_at, updated_at, name
+RETURNING id, created_at, updated_at, name, last_read_post_at
 `
 
 type CreateUserParams struct {
@@ -43,12 +71,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Name,
+		&i.LastReadPostAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, created_at, updated_at, name FROM users WHERE name = $1
+SELECT id, created_at, updated_at, name, last_read_post_at FROM users WHERE name = $1
 `
 
 func (q *Queries) GetUser(ctx context.Context, name string) (User, error) {
@@ -59,12 +88,13 @@ func (q *Queries) GetUser(ctx context.Context, name string) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Name,
+		&i.LastReadPostAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, created_at, updated_at, name FROM users WHERE id = $1
+SELECT id, created_at, updated_at, name, last_read_post_at FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
@@ -75,6 +105,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Name,
+		&i.LastReadPostAt,
 	)
 	return i, err
 }
@@ -114,3 +145,28 @@ func (q *Queries) Reset(ctx context.Context) error {
 	_, err := q.db.ExecContext(ctx, reset)
 	return err
 }
+
+const updateUserName = `-- name: UpdateUserName :one
+UPDATE users
+SET name = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1
+RETURNING id, created_at, updated_at, name, last_read_post_at
+`
+
+type UpdateUserNameParams struct {
+	ID   uuid.UUID
+	Name string
+}
+
+func (q *Queries) UpdateUserName(ctx context.Context, arg UpdateUserNameParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserName, arg.ID, arg.Name)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.LastReadPostAt,
+	)
+	return i, err
+}
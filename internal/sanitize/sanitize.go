@@ -0,0 +1,14 @@
+package sanitize
+
+import "github.com/microcosm-cc/bluemonday"
+
+// policy is safe to share once built; bluemonday policies are only read when
+// sanitizing.
+var policy = bluemonday.UGCPolicy()
+
+// HTML strips scripts, event handlers and anything else unsafe from
+// feed HTML, keeping ordinary formatting, links and images. Posts are stored
+// as fetched; this is for whenever their HTML is handed on.
+func HTML(html string) string {
+	return policy.Sanitize(html)
+}
@@ -0,0 +1,11 @@
+-- name: GetAppState :one
+SELECT value FROM app_state WHERE name = $1;
+
+-- name: SetAppState :exec
+INSERT INTO app_state (name, value, updated_at)
+VALUES ($1, $2, LOCALTIMESTAMP)
+ON CONFLICT (name) DO UPDATE
+SET value = EXCLUDED.value, updated_at = LOCALTIMESTAMP;
+
+-- name: DeleteAppState :exec
+DELETE FROM app_state WHERE name = $1;
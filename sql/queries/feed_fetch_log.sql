@@ -0,0 +1,17 @@
+-- name: CreateFeedFetchLog :exec
+INSERT INTO feed_fetch_log (id, created_at, feed_id, status, items, error)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetFeedFetchLog :many
+SELECT * FROM feed_fetch_log
+WHERE feed_id = $1
+ORDER BY created_at DESC;
+
+-- name: PruneFeedFetchLog :exec
+DELETE FROM feed_fetch_log
+WHERE feed_fetch_log.feed_id = sqlc.arg(feed_id) AND feed_fetch_log.id NOT IN (
+	SELECT kept.id FROM feed_fetch_log AS kept
+	WHERE kept.feed_id = sqlc.arg(feed_id)
+	ORDER BY kept.created_at DESC
+	LIMIT sqlc.arg(keep)
+);
@@ -1,12 +1,14 @@
 -- name: CreateFeedFollow :one
 WITH inserted_feed_follow AS (
-	INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id)
+	INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id, alias, paused)
 	VALUES (
 		$1,
 		$2,
 		$3,
 		$4,
-		$5
+		$5,
+		$6,
+		$7
 	) RETURNING *
 )
 SELECT
@@ -18,11 +20,77 @@ FROM inserted_feed_follow
 	INNER JOIN feeds ON inserted_feed_follow.feed_id = feeds.id;
 
 -- name: GetFeedFollowsForUser :many
-SELECT feed_follows.*, users.name AS user_name, feeds.name AS feed_name, feeds.url AS url
+SELECT feed_follows.*, users.name AS user_name,
+	COALESCE(feed_follows.alias, feeds.name) AS feed_name, feeds.url AS url,
+	owners.name AS owner_name,
+	(SELECT MAX(posts.published_at) FROM posts WHERE posts.feed_id = feeds.id)::timestamp AS latest_post_at
 FROM feed_follows
 	INNER JOIN users ON feed_follows.user_id = users.id
 	INNER JOIN feeds ON feed_follows.feed_id = feeds.id
-WHERE users.id = $1;
+	INNER JOIN users AS owners ON feeds.user_id = owners.id
+WHERE users.id = $1
+ORDER BY feed_name;
 
 -- name: UnfollowFeed :exec
 DELETE FROM feed_follows WHERE user_id = $1 AND feed_id = $2;
+
+-- name: FollowAllFeeds :execrows
+INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id)
+SELECT gen_random_uuid(), LOCALTIMESTAMP, LOCALTIMESTAMP, $1, feeds.id
+FROM feeds
+WHERE NOT EXISTS (
+	SELECT 1 FROM feed_follows
+	WHERE feed_follows.user_id = $1 AND feed_follows.feed_id = feeds.id
+);
+
+-- name: MoveFeedFollows :execrows
+UPDATE feed_follows
+SET feed_id = sqlc.arg(to_feed_id), updated_at = LOCALTIMESTAMP
+WHERE feed_id = sqlc.arg(from_feed_id)
+	AND NOT EXISTS (
+		SELECT 1 FROM feed_follows AS kept
+		WHERE kept.feed_id = sqlc.arg(to_feed_id)
+			AND kept.user_id = feed_follows.user_id
+	);
+
+-- name: MergeFeedFollows :exec
+UPDATE feed_follows AS kept
+SET alias = COALESCE(kept.alias, merged.alias),
+	paused = kept.paused AND merged.paused,
+	updated_at = LOCALTIMESTAMP
+FROM feed_follows AS merged
+WHERE kept.feed_id = sqlc.arg(to_feed_id)
+	AND merged.feed_id = sqlc.arg(from_feed_id)
+	AND merged.user_id = kept.user_id;
+
+-- name: RecommendFeeds :many
+SELECT feeds.id, feeds.name, feeds.url, COUNT(*) AS overlap
+FROM feed_follows AS mine
+	INNER JOIN feed_follows AS peers
+		ON peers.feed_id = mine.feed_id AND peers.user_id <> mine.user_id
+	INNER JOIN feed_follows AS theirs ON theirs.user_id = peers.user_id
+	INNER JOIN feeds ON theirs.feed_id = feeds.id
+WHERE mine.user_id = sqlc.arg(user_id)
+	AND NOT EXISTS (
+		SELECT 1 FROM feed_follows
+		WHERE feed_follows.user_id = sqlc.arg(user_id) AND feed_follows.feed_id = feeds.id
+	)
+GROUP BY feeds.id, feeds.name, feeds.url
+ORDER BY overlap DESC, feeds.name
+LIMIT sqlc.arg(max_feeds);
+
+-- name: SetFeedFollowAlias :exec
+UPDATE feed_follows
+SET alias = $3, updated_at = LOCALTIMESTAMP
+WHERE user_id = $1 AND feed_id = $2;
+
+-- name: SetFeedFollowPaused :execrows
+UPDATE feed_follows
+SET paused = $3, updated_at = LOCALTIMESTAMP
+WHERE user_id = $1 AND feed_id = $2;
+
+-- name: GetFeedFollowers :many
+SELECT users.name FROM feed_follows
+	INNER JOIN users ON feed_follows.user_id = users.id
+WHERE feed_follows.feed_id = $1
+ORDER BY users.name;
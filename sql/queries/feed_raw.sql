@@ -0,0 +1,18 @@
+-- name: CreateFeedRaw :exec
+INSERT INTO feed_raw (id, created_at, feed_id, body)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetLatestFeedRaw :one
+SELECT * FROM feed_raw
+WHERE feed_id = $1
+ORDER BY created_at DESC
+FETCH FIRST ROW ONLY;
+
+-- name: PruneFeedRaw :exec
+DELETE FROM feed_raw
+WHERE feed_raw.feed_id = sqlc.arg(feed_id) AND feed_raw.id NOT IN (
+	SELECT kept.id FROM feed_raw AS kept
+	WHERE kept.feed_id = sqlc.arg(feed_id)
+	ORDER BY kept.created_at DESC
+	LIMIT sqlc.arg(keep)
+);
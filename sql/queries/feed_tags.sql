@@ -0,0 +1,22 @@
+-- name: TagFeed :exec
+INSERT INTO feed_tags (id, created_at, updated_at, feed_id, tag)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (feed_id, tag) DO NOTHING;
+
+-- name: UntagFeed :execrows
+DELETE FROM feed_tags WHERE feed_id = $1 AND tag = $2;
+
+-- name: GetTags :many
+SELECT tag, COUNT(*) AS feed_count
+FROM feed_tags
+GROUP BY tag
+ORDER BY tag;
+
+-- name: MoveFeedTags :exec
+UPDATE feed_tags
+SET feed_id = sqlc.arg(to_feed_id), updated_at = LOCALTIMESTAMP
+WHERE feed_id = sqlc.arg(from_feed_id)
+	AND NOT EXISTS (
+		SELECT 1 FROM feed_tags AS kept
+		WHERE kept.feed_id = sqlc.arg(to_feed_id) AND kept.tag = feed_tags.tag
+	);
@@ -11,18 +11,93 @@ VALUES (
 RETURNING *;
 
 -- name: GetFeeds :many
-SELECT feeds.name, feeds.url, users.name AS username
-FROM feeds INNER JOIN users ON feeds.user_id = users.id;
+SELECT feeds.name, feeds.url, users.name AS username, feeds.last_fetched_at,
+	feeds.created_at, feeds.failure_count,
+	(SELECT COUNT(*) FROM posts WHERE posts.feed_id = feeds.id) AS post_count,
+	(SELECT COUNT(*) FROM feed_follows WHERE feed_follows.feed_id = feeds.id) AS follower_count,
+	(SELECT MAX(posts.published_at) FROM posts WHERE posts.feed_id = feeds.id)::timestamp AS newest_post_at
+FROM feeds INNER JOIN users ON feeds.user_id = users.id
+WHERE (sqlc.narg(owner)::text IS NULL OR users.name = sqlc.narg(owner))
+	AND (sqlc.narg(added_after)::timestamp IS NULL
+		OR feeds.created_at > sqlc.narg(added_after))
+ORDER BY
+	CASE WHEN sqlc.narg(added_after)::timestamp IS NOT NULL THEN feeds.created_at END DESC;
 
 -- name: GetFeedByURL :one
 SELECT * FROM feeds WHERE url = $1;
 
+-- name: GetFeedsByName :many
+SELECT * FROM feeds WHERE name = $1 ORDER BY created_at;
+
 -- name: MarkFeedFetched :exec
 UPDATE feeds
-SET last_fetched_at = LOCALTIMESTAMP, updated_at = LOCALTIMESTAMP
+SET last_fetched_at = LOCALTIMESTAMP, last_attempt_at = LOCALTIMESTAMP,
+	failure_count = 0, updated_at = LOCALTIMESTAMP
+WHERE id = $1;
+
+-- name: MarkFeedFailed :exec
+UPDATE feeds
+SET last_attempt_at = LOCALTIMESTAMP, failure_count = failure_count + 1,
+	updated_at = LOCALTIMESTAMP
 WHERE id = $1;
 
 -- name: GetNextFeedToFetch :one
 SELECT * FROM feeds
-ORDER BY last_fetched_at NULLS FIRST
+WHERE (retry_after_at IS NULL OR retry_after_at <= LOCALTIMESTAMP)
+	AND (last_fetched_at IS NULL
+		OR last_fetched_at + COALESCE(make_interval(mins => ttl_minutes),
+			make_interval(secs => sqlc.arg(default_interval_seconds)::float8)) <= LOCALTIMESTAMP)
+ORDER BY last_attempt_at + make_interval(secs => random() * sqlc.arg(jitter_seconds)::float8) NULLS FIRST
+FETCH FIRST ROW ONLY;
+
+-- name: GetNextFollowedFeedToFetch :one
+SELECT * FROM feeds
+WHERE EXISTS (SELECT 1 FROM feed_follows WHERE feed_follows.feed_id = feeds.id)
+	AND (retry_after_at IS NULL OR retry_after_at <= LOCALTIMESTAMP)
+	AND (last_fetched_at IS NULL
+		OR last_fetched_at + COALESCE(make_interval(mins => ttl_minutes),
+			make_interval(secs => sqlc.arg(default_interval_seconds)::float8)) <= LOCALTIMESTAMP)
+ORDER BY last_attempt_at + make_interval(secs => random() * sqlc.arg(jitter_seconds)::float8) NULLS FIRST
 FETCH FIRST ROW ONLY;
+
+-- name: SetFeedRetryAfter :exec
+UPDATE feeds
+SET retry_after_at = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1;
+
+-- name: SetFeedAuthToken :exec
+UPDATE feeds
+SET auth_token = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1;
+
+-- name: DeleteFeed :exec
+DELETE FROM feeds WHERE id = $1;
+
+-- name: GetFeedsToFetch :many
+SELECT * FROM feeds
+WHERE (retry_after_at IS NULL OR retry_after_at <= LOCALTIMESTAMP)
+	AND (NOT sqlc.arg(followed_only)::boolean OR EXISTS (
+		SELECT 1 FROM feed_follows WHERE feed_follows.feed_id = feeds.id))
+	AND (last_fetched_at IS NULL
+		OR last_fetched_at + COALESCE(make_interval(mins => ttl_minutes),
+			make_interval(secs => sqlc.arg(default_interval_seconds)::float8)) <= LOCALTIMESTAMP)
+ORDER BY last_attempt_at NULLS FIRST;
+
+-- name: SetFeedTTL :exec
+UPDATE feeds
+SET ttl_minutes = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1;
+
+-- name: ListFeedsForUser :many
+SELECT * FROM feeds
+WHERE feeds.user_id = sqlc.arg(user_id)
+	OR (NOT sqlc.arg(owned_only)::boolean AND EXISTS (
+		SELECT 1 FROM feed_follows
+		WHERE feed_follows.feed_id = feeds.id
+			AND feed_follows.user_id = sqlc.arg(user_id)))
+ORDER BY name;
+
+-- name: SetFeedName :exec
+UPDATE feeds
+SET name = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1;
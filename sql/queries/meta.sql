@@ -0,0 +1,5 @@
+-- name: TableExists :one
+SELECT EXISTS (
+	SELECT 1 FROM information_schema.tables
+	WHERE table_schema = current_schema() AND table_name = $1
+);
@@ -0,0 +1,17 @@
+-- name: StarPost :exec
+INSERT INTO post_stars (id, created_at, updated_at, user_id, post_id)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, post_id) DO NOTHING;
+
+-- name: UnstarPost :execrows
+DELETE FROM post_stars WHERE user_id = $1 AND post_id = $2;
+
+-- name: GetStarredPosts :many
+SELECT posts.* FROM post_stars
+	INNER JOIN posts ON post_stars.post_id = posts.id
+WHERE post_stars.user_id = $1
+ORDER BY post_stars.created_at DESC
+LIMIT $2;
+
+-- name: DeleteStarsForUser :execrows
+DELETE FROM post_stars WHERE user_id = $1;
@@ -1,14 +1,89 @@
 -- name: CreatePost :one
 INSERT INTO posts
-(id, created_at, updated_at, title, url, description, published_at, feed_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+(id, created_at, updated_at, title, url, description, published_at, feed_id,
+	plain_description, content, word_count)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (url) DO NOTHING
 RETURNING *;
 
 -- name: GetPostsForUser :many
-SELECT posts.* FROM
+SELECT posts.* FROM posts
+WHERE posts.id IN (
+	SELECT ranked.id FROM (
+		SELECT posts.id,
+			ROW_NUMBER() OVER (
+				PARTITION BY posts.feed_id
+				ORDER BY
+					CASE WHEN sqlc.arg(oldest_first)::boolean THEN posts.published_at END ASC,
+					posts.published_at DESC
+			) AS feed_rank
+		FROM feed_follows
+			INNER JOIN posts ON feed_follows.feed_id = posts.feed_id
+		WHERE feed_follows.user_id = sqlc.arg(user_id)
+			AND (sqlc.narg(published_after)::timestamp IS NULL
+				OR posts.published_at > sqlc.narg(published_after))
+			AND (sqlc.narg(created_after)::timestamp IS NULL
+				OR posts.created_at > sqlc.narg(created_after))
+			AND (sqlc.narg(tag)::text IS NULL OR posts.feed_id IN (
+				SELECT feed_tags.feed_id FROM feed_tags WHERE feed_tags.tag = sqlc.narg(tag)))
+			AND (sqlc.narg(feed_ids)::uuid[] IS NULL
+				OR posts.feed_id = ANY(sqlc.narg(feed_ids)::uuid[]))
+			AND (NOT sqlc.arg(has_media)::boolean OR EXISTS (
+				SELECT 1 FROM posts_media WHERE posts_media.post_id = posts.id))
+			AND (sqlc.arg(include_paused)::boolean OR NOT feed_follows.paused)
+	) AS ranked
+	WHERE sqlc.narg(max_per_feed)::int IS NULL
+		OR ranked.feed_rank <= sqlc.narg(max_per_feed)
+)
+ORDER BY
+	CASE WHEN sqlc.arg(oldest_first)::boolean THEN posts.published_at END ASC,
+	posts.published_at DESC
+LIMIT sqlc.narg(max_posts);
+
+-- name: GetPostByURL :one
+SELECT posts.*, feeds.name AS feed_name
+FROM posts INNER JOIN feeds ON posts.feed_id = feeds.id
+WHERE posts.url = $1;
+
+-- name: DeleteDuplicatePosts :execrows
+DELETE FROM posts
+WHERE posts.id IN (
+	SELECT later.id FROM posts AS later
+		INNER JOIN posts AS earlier
+		ON lower(trim(earlier.title)) = lower(trim(later.title))
+			AND (earlier.published_at < later.published_at
+				OR (earlier.published_at = later.published_at AND earlier.id < later.id))
+	WHERE trim(later.title) <> ''
+		AND EXTRACT(EPOCH FROM later.published_at - earlier.published_at) <= sqlc.arg(window_seconds)::float8
+		AND later.feed_id IN (SELECT feeds.id FROM feeds WHERE feeds.user_id = sqlc.arg(user_id))
+		AND earlier.feed_id IN (SELECT feeds.id FROM feeds WHERE feeds.user_id = sqlc.arg(user_id))
+);
+
+-- name: MoveFeedPosts :execrows
+UPDATE posts
+SET feed_id = sqlc.arg(to_feed_id), updated_at = LOCALTIMESTAMP
+WHERE feed_id = sqlc.arg(from_feed_id);
+
+-- name: GetPostsCreatedAfter :many
+SELECT posts.*, feeds.name AS feed_name
+FROM posts INNER JOIN feeds ON posts.feed_id = feeds.id
+WHERE posts.created_at > $1
+ORDER BY feeds.name, posts.published_at DESC;
+
+-- name: CountPostsForUser :one
+SELECT COUNT(*) FROM
 feed_follows
-	INNER JOIN users ON users.id = feed_follows.user_id
 	INNER JOIN posts ON feed_follows.feed_id = posts.feed_id
-WHERE users.id = $1
-ORDER BY posts.published_at DESC
-LIMIT $2;
+WHERE feed_follows.user_id = sqlc.arg(user_id)
+	AND (sqlc.narg(published_after)::timestamp IS NULL
+		OR posts.published_at > sqlc.narg(published_after))
+	AND (sqlc.narg(tag)::text IS NULL OR posts.feed_id IN (
+		SELECT feed_tags.feed_id FROM feed_tags WHERE feed_tags.tag = sqlc.narg(tag)))
+	AND (sqlc.narg(feed_ids)::uuid[] IS NULL
+		OR posts.feed_id = ANY(sqlc.narg(feed_ids)::uuid[]))
+	AND (NOT sqlc.arg(has_media)::boolean OR EXISTS (
+		SELECT 1 FROM posts_media WHERE posts_media.post_id = posts.id))
+	AND (sqlc.arg(include_paused)::boolean OR NOT feed_follows.paused);
+
+-- name: DeleteFeedPosts :execrows
+DELETE FROM posts WHERE feed_id = $1;
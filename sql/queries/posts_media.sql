@@ -0,0 +1,9 @@
+-- name: CreatePostMedia :exec
+INSERT INTO posts_media (id, created_at, updated_at, post_id, url, media_type, length)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (post_id, url) DO NOTHING;
+
+-- name: GetMediaForPosts :many
+SELECT * FROM posts_media
+WHERE post_id = ANY(sqlc.arg(post_ids)::uuid[])
+ORDER BY created_at;
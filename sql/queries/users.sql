@@ -19,3 +19,20 @@ SELECT name FROM users;
 
 -- name: GetUserByID :one
 SELECT * FROM users WHERE id = $1;
+
+-- name: AdvanceLastReadPostAt :exec
+UPDATE users
+SET last_read_post_at = GREATEST(last_read_post_at, sqlc.arg(last_read_post_at)::timestamp),
+	updated_at = LOCALTIMESTAMP
+WHERE id = $1;
+
+-- name: ClearLastReadPostAt :exec
+UPDATE users
+SET last_read_post_at = NULL, updated_at = LOCALTIMESTAMP
+WHERE id = $1;
+
+-- name: UpdateUserName :one
+UPDATE users
+SET name = $2, updated_at = LOCALTIMESTAMP
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE posts ADD COLUMN plain_description text NOT NULL DEFAULT '';
+UPDATE posts SET plain_description = regexp_replace(description, '<[^>]*>', '', 'g');
+
+-- +goose Down
+ALTER TABLE posts DROP COLUMN plain_description;
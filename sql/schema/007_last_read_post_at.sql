@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN last_read_post_at timestamp;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN last_read_post_at;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- last_fetched_at now only records successful fetches; attempts, successful or
+-- not, are recorded here and drive the fetch rotation.
+ALTER TABLE feeds ADD COLUMN last_attempt_at timestamp;
+UPDATE feeds SET last_attempt_at = last_fetched_at;
+
+-- +goose Down
+ALTER TABLE feeds DROP COLUMN last_attempt_at;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE feed_tags (
+	id uuid PRIMARY KEY,
+	created_at timestamp NOT NULL,
+	updated_at timestamp NOT NULL,
+	feed_id uuid NOT NULL REFERENCES feeds ON DELETE CASCADE,
+	tag text NOT NULL,
+	CONSTRAINT no_dupe_tags UNIQUE(feed_id, tag)
+);
+
+-- +goose Down
+DROP TABLE feed_tags;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE feeds ADD COLUMN retry_after_at timestamp;
+
+-- +goose Down
+ALTER TABLE feeds DROP COLUMN retry_after_at;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Stored in plaintext; anyone who can read the database can read these.
+ALTER TABLE feeds ADD COLUMN auth_token text;
+
+-- +goose Down
+ALTER TABLE feeds DROP COLUMN auth_token;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE post_stars (
+	id uuid PRIMARY KEY,
+	created_at timestamp NOT NULL,
+	updated_at timestamp NOT NULL,
+	user_id uuid NOT NULL REFERENCES users ON DELETE CASCADE,
+	post_id uuid NOT NULL REFERENCES posts ON DELETE CASCADE,
+	CONSTRAINT no_dupe_stars UNIQUE(user_id, post_id)
+);
+
+-- +goose Down
+DROP TABLE post_stars;
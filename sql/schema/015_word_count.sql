@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE posts ADD COLUMN word_count integer NOT NULL DEFAULT 0;
+UPDATE posts SET word_count = COALESCE(array_length(regexp_split_to_array(
+	trim(regexp_replace(
+		CASE WHEN '' = content THEN description ELSE content END,
+		'<[^>]*>', ' ', 'g')), '\s+'), 1), 0)
+WHERE '' != trim(regexp_replace(
+	CASE WHEN '' = content THEN description ELSE content END,
+	'<[^>]*>', ' ', 'g'));
+
+-- +goose Down
+ALTER TABLE posts DROP COLUMN word_count;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE posts_media (
+	id uuid PRIMARY KEY,
+	created_at timestamp NOT NULL,
+	updated_at timestamp NOT NULL,
+	post_id uuid NOT NULL REFERENCES posts ON DELETE CASCADE,
+	url text NOT NULL,
+	media_type text NOT NULL DEFAULT '',
+	length bigint,
+	CONSTRAINT no_dupe_media UNIQUE(post_id, url)
+);
+
+-- +goose Down
+DROP TABLE posts_media;
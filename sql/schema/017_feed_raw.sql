@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE feed_raw (
+	id uuid PRIMARY KEY,
+	created_at timestamp NOT NULL,
+	feed_id uuid NOT NULL REFERENCES feeds ON DELETE CASCADE,
+	body bytea NOT NULL
+);
+CREATE INDEX feed_raw_feed_id_created_at ON feed_raw (feed_id, created_at);
+
+-- +goose Down
+DROP TABLE feed_raw;
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE app_state (
+	name text PRIMARY KEY,
+	value text NOT NULL,
+	updated_at timestamp NOT NULL
+);
+
+-- +goose Down
+DROP TABLE app_state;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE feeds ADD COLUMN ttl_minutes int;
+
+-- +goose Down
+ALTER TABLE feeds DROP COLUMN ttl_minutes;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE feed_fetch_log (
+	id uuid PRIMARY KEY,
+	created_at timestamp NOT NULL,
+	feed_id uuid NOT NULL REFERENCES feeds ON DELETE CASCADE,
+	status text NOT NULL,
+	items int NOT NULL,
+	error text
+);
+CREATE INDEX feed_fetch_log_feed_id_created_at ON feed_fetch_log (feed_id, created_at);
+
+-- +goose Down
+DROP TABLE feed_fetch_log;
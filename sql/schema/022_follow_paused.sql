@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE feed_follows ADD COLUMN paused boolean NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE feed_follows DROP COLUMN paused;